@@ -0,0 +1,308 @@
+package gen
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// Column describes a single table column. Type is the raw Postgres udt_name
+// (eg. "int4", "_text" for an int4 array); each Target maps it through its
+// own pgtype -> language table.
+type Column struct {
+	Name       string
+	Type       string
+	IsNullable bool
+	HasDefault bool
+}
+
+// ForeignKey describes a single foreign key constraint on a table, in the
+// same shape pg-meta's typescript generator exposes as a table's
+// Relationships array.
+type ForeignKey struct {
+	ConstraintName    string
+	Columns           []string
+	ReferencedSchema  string
+	ReferencedTable   string
+	ReferencedColumns []string
+}
+
+// Table describes a Postgres table (or view), its columns, and the foreign
+// keys declared on it.
+type Table struct {
+	Name        string
+	Columns     []Column
+	ForeignKeys []ForeignKey
+}
+
+// Enum describes a Postgres enum and its ordered labels.
+type Enum struct {
+	Name   string
+	Values []string
+}
+
+// Composite describes a Postgres composite (row) type and its fields.
+type Composite struct {
+	Name   string
+	Fields []Column
+}
+
+// Schema groups the tables, enums, and composite types introspected from a
+// single Postgres schema.
+type Schema struct {
+	Name       string
+	Tables     []Table
+	Enums      []Enum
+	Composites []Composite
+}
+
+// Catalog is the root of the introspected schema tree, rendered into each
+// language-specific target.
+type Catalog struct {
+	Schemas []Schema
+}
+
+// columnsSql mirrors the columns query pg-meta issues against
+// information_schema, filtered in Go by the requested schemas so the script
+// takes no parameters.
+const columnsSql = `
+SELECT
+  table_schema,
+  table_name,
+  column_name,
+  udt_name,
+  is_nullable,
+  (column_default IS NOT NULL) AS has_default
+FROM information_schema.columns
+WHERE table_schema NOT IN ('pg_catalog', 'information_schema')
+ORDER BY table_schema, table_name, ordinal_position
+`
+
+// enumsSql mirrors the enums query pg-meta issues against pg_catalog,
+// filtered in Go by the requested schemas so the script takes no parameters.
+const enumsSql = `
+SELECT
+  n.nspname AS table_schema,
+  t.typname AS name,
+  e.enumlabel AS value
+FROM pg_type t
+JOIN pg_enum e ON t.oid = e.enumtypid
+JOIN pg_namespace n ON t.typnamespace = n.oid
+ORDER BY n.nspname, t.typname, e.enumsortorder
+`
+
+// compositesSql mirrors the composite type query pg-meta issues against
+// pg_catalog, filtered in Go by the requested schemas so the script takes no
+// parameters.
+const compositesSql = `
+SELECT
+  n.nspname AS table_schema,
+  t.typname AS name,
+  a.attname AS field_name,
+  at.typname AS field_type,
+  NOT a.attnotnull AS is_nullable
+FROM pg_type t
+JOIN pg_namespace n ON t.typnamespace = n.oid
+JOIN pg_attribute a ON a.attrelid = t.typrelid
+JOIN pg_type at ON a.atttypid = at.oid
+WHERE t.typtype = 'c'
+  AND a.attnum > 0
+  AND NOT a.attisdropped
+ORDER BY n.nspname, t.typname, a.attnum
+`
+
+// foreignKeysSql mirrors the foreign key query pg-meta issues against
+// pg_catalog. Columns are returned comma-joined in declaration order rather
+// than as a Postgres array, so a single untyped text column is enough to
+// script and decode a (possibly multi-column) constraint.
+const foreignKeysSql = `
+SELECT
+  n.nspname AS table_schema,
+  c.relname AS table_name,
+  con.conname AS constraint_name,
+  (
+    SELECT string_agg(a.attname, ',' ORDER BY k.ord)
+    FROM unnest(con.conkey) WITH ORDINALITY AS k(attnum, ord)
+    JOIN pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+  ) AS columns,
+  fn.nspname AS referenced_schema,
+  fc.relname AS referenced_table,
+  (
+    SELECT string_agg(a.attname, ',' ORDER BY k.ord)
+    FROM unnest(con.confkey) WITH ORDINALITY AS k(attnum, ord)
+    JOIN pg_attribute a ON a.attrelid = con.confrelid AND a.attnum = k.attnum
+  ) AS referenced_columns
+FROM pg_constraint con
+JOIN pg_class c ON c.oid = con.conrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_class fc ON fc.oid = con.confrelid
+JOIN pg_namespace fn ON fn.oid = fc.relnamespace
+WHERE con.contype = 'f'
+ORDER BY n.nspname, c.relname, con.conname
+`
+
+// loadCatalog introspects columns, enums, composite types and foreign keys
+// across the given schemas directly via pgx, replacing the pg-meta container
+// round trip.
+func loadCatalog(ctx context.Context, conn *pgx.Conn, schemas []string) (*Catalog, error) {
+	wanted := make(map[string]int, len(schemas))
+	catalog := Catalog{}
+	for _, name := range schemas {
+		wanted[name] = len(catalog.Schemas)
+		catalog.Schemas = append(catalog.Schemas, Schema{Name: name})
+	}
+
+	if err := loadColumns(ctx, conn, &catalog, wanted); err != nil {
+		return nil, err
+	}
+	if err := loadEnums(ctx, conn, &catalog, wanted); err != nil {
+		return nil, err
+	}
+	if err := loadComposites(ctx, conn, &catalog, wanted); err != nil {
+		return nil, err
+	}
+	if err := loadForeignKeys(ctx, conn, &catalog, wanted); err != nil {
+		return nil, err
+	}
+	return &catalog, nil
+}
+
+func loadColumns(ctx context.Context, conn *pgx.Conn, catalog *Catalog, wanted map[string]int) error {
+	rows, err := conn.Query(ctx, columnsSql)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	tableIndex := map[string]int{}
+	for rows.Next() {
+		var schemaName, tableName, columnName, udtName, isNullable string
+		var hasDefault bool
+		if err := rows.Scan(&schemaName, &tableName, &columnName, &udtName, &isNullable, &hasDefault); err != nil {
+			return err
+		}
+		si, ok := wanted[schemaName]
+		if !ok {
+			continue
+		}
+		key := schemaName + "." + tableName
+		ti, ok := tableIndex[key]
+		if !ok {
+			catalog.Schemas[si].Tables = append(catalog.Schemas[si].Tables, Table{Name: tableName})
+			ti = len(catalog.Schemas[si].Tables) - 1
+			tableIndex[key] = ti
+		}
+		catalog.Schemas[si].Tables[ti].Columns = append(catalog.Schemas[si].Tables[ti].Columns, Column{
+			Name:       columnName,
+			Type:       udtName,
+			IsNullable: isNullable == "YES",
+			HasDefault: hasDefault,
+		})
+	}
+	return rows.Err()
+}
+
+func loadEnums(ctx context.Context, conn *pgx.Conn, catalog *Catalog, wanted map[string]int) error {
+	rows, err := conn.Query(ctx, enumsSql)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	enumIndex := map[string]int{}
+	for rows.Next() {
+		var schemaName, enumName, value string
+		if err := rows.Scan(&schemaName, &enumName, &value); err != nil {
+			return err
+		}
+		si, ok := wanted[schemaName]
+		if !ok {
+			continue
+		}
+		key := schemaName + "." + enumName
+		ei, ok := enumIndex[key]
+		if !ok {
+			catalog.Schemas[si].Enums = append(catalog.Schemas[si].Enums, Enum{Name: enumName})
+			ei = len(catalog.Schemas[si].Enums) - 1
+			enumIndex[key] = ei
+		}
+		catalog.Schemas[si].Enums[ei].Values = append(catalog.Schemas[si].Enums[ei].Values, value)
+	}
+	return rows.Err()
+}
+
+func loadComposites(ctx context.Context, conn *pgx.Conn, catalog *Catalog, wanted map[string]int) error {
+	rows, err := conn.Query(ctx, compositesSql)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	compositeIndex := map[string]int{}
+	for rows.Next() {
+		var schemaName, typeName, fieldName, fieldType string
+		var isNullable bool
+		if err := rows.Scan(&schemaName, &typeName, &fieldName, &fieldType, &isNullable); err != nil {
+			return err
+		}
+		si, ok := wanted[schemaName]
+		if !ok {
+			continue
+		}
+		key := schemaName + "." + typeName
+		ci, ok := compositeIndex[key]
+		if !ok {
+			catalog.Schemas[si].Composites = append(catalog.Schemas[si].Composites, Composite{Name: typeName})
+			ci = len(catalog.Schemas[si].Composites) - 1
+			compositeIndex[key] = ci
+		}
+		catalog.Schemas[si].Composites[ci].Fields = append(catalog.Schemas[si].Composites[ci].Fields, Column{
+			Name:       fieldName,
+			Type:       fieldType,
+			IsNullable: isNullable,
+		})
+	}
+	return rows.Err()
+}
+
+// tableRef locates a table within catalog.Schemas by its schema and table
+// indices.
+type tableRef struct{ si, ti int }
+
+func loadForeignKeys(ctx context.Context, conn *pgx.Conn, catalog *Catalog, wanted map[string]int) error {
+	rows, err := conn.Query(ctx, foreignKeysSql)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	tableIndex := map[string]tableRef{}
+	for si, s := range catalog.Schemas {
+		for ti, t := range s.Tables {
+			tableIndex[s.Name+"."+t.Name] = tableRef{si: si, ti: ti}
+		}
+	}
+
+	for rows.Next() {
+		var schemaName, tableName, constraintName, columns, refSchema, refTable, refColumns string
+		if err := rows.Scan(&schemaName, &tableName, &constraintName, &columns, &refSchema, &refTable, &refColumns); err != nil {
+			return err
+		}
+		if _, ok := wanted[schemaName]; !ok {
+			continue
+		}
+		ref, ok := tableIndex[schemaName+"."+tableName]
+		if !ok {
+			continue
+		}
+		catalog.Schemas[ref.si].Tables[ref.ti].ForeignKeys = append(catalog.Schemas[ref.si].Tables[ref.ti].ForeignKeys, ForeignKey{
+			ConstraintName:    constraintName,
+			Columns:           strings.Split(columns, ","),
+			ReferencedSchema:  refSchema,
+			ReferencedTable:   refTable,
+			ReferencedColumns: strings.Split(refColumns, ","),
+		})
+	}
+	return rows.Err()
+}
@@ -0,0 +1,150 @@
+package golang
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/supabase/cli/internal/gen"
+)
+
+func init() {
+	gen.Register("go", Target{})
+}
+
+// Target renders an introspected catalog as plain Go structs.
+type Target struct{}
+
+func (Target) Render(catalog *gen.Catalog) ([]byte, error) {
+	tmpl, err := template.New("go").Funcs(template.FuncMap{
+		"goType":   pgTypeToGo,
+		"exported": exportedName,
+	}).Parse(goTemplate)
+	if err != nil {
+		return nil, err
+	}
+	data := struct {
+		*gen.Catalog
+		UsesJSON bool
+	}{catalog, usesJSON(catalog)}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// usesJSON reports whether any column across the catalog maps to
+// json.RawMessage, so the generated file only imports encoding/json when it
+// is actually referenced.
+func usesJSON(catalog *gen.Catalog) bool {
+	isJSON := func(udtName string) bool {
+		switch pgTypeToGo(udtName) {
+		case "json.RawMessage", "[]json.RawMessage":
+			return true
+		}
+		return false
+	}
+	for _, s := range catalog.Schemas {
+		for _, t := range s.Tables {
+			for _, c := range t.Columns {
+				if isJSON(c.Type) {
+					return true
+				}
+			}
+		}
+		for _, c := range s.Composites {
+			for _, f := range c.Fields {
+				if isJSON(f.Type) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// pgToGo maps Postgres udt_name values to their Go equivalent.
+var pgToGo = map[string]string{
+	"int2": "int16", "int4": "int32", "int8": "int64",
+	"float4": "float32", "float8": "float64", "numeric": "float64",
+	"bool": "bool",
+	"json": "json.RawMessage", "jsonb": "json.RawMessage",
+	"uuid": "string", "text": "string", "varchar": "string", "bpchar": "string",
+	"date": "string", "timestamp": "string", "timestamptz": "string", "time": "string", "timetz": "string",
+}
+
+func pgTypeToGo(udtName string) string {
+	name := udtName
+	isArray := false
+	if len(name) > 1 && name[0] == '_' {
+		isArray = true
+		name = name[1:]
+	}
+	goType, ok := pgToGo[name]
+	if !ok {
+		goType = "interface{}"
+	}
+	if isArray {
+		goType = "[]" + goType
+	}
+	return goType
+}
+
+// exportedName converts a snake_case Postgres identifier, or an arbitrary
+// enum label, into an exported Go identifier, eg. "user_id" -> "UserId" and
+// "in progress" -> "InProgress". Splits on any non-alphanumeric rune so
+// labels containing spaces or punctuation still produce a valid identifier.
+func exportedName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, p := range parts {
+		if len(p) > 0 {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+const goTemplate = `// Code generated by supabase gen types go. DO NOT EDIT.
+package database
+{{if .UsesJSON}}
+import "encoding/json"
+{{end}}
+// Relationship describes a single foreign key constraint, identical in
+// shape across every gen types target.
+type Relationship struct {
+	ConstraintName    string   ` + "`json:\"constraint_name\"`" + `
+	Columns           []string ` + "`json:\"columns\"`" + `
+	ReferencedTable   string   ` + "`json:\"referenced_table\"`" + `
+	ReferencedColumns []string ` + "`json:\"referenced_columns\"`" + `
+}
+{{range .Schemas}}
+{{range .Enums}}
+{{$enum := .}}
+type {{exported $enum.Name}} string
+
+const (
+{{range $enum.Values}}	{{exported $enum.Name}}{{exported .}} {{exported $enum.Name}} = "{{.}}"
+{{end}}
+)
+{{end}}
+{{range .Composites}}
+{{$composite := .}}
+type {{exported $composite.Name}} struct {
+{{range $composite.Fields}}	{{exported .Name}} {{if .IsNullable}}*{{end}}{{goType .Type}} ` + "`json:\"{{.Name}}\"`" + `
+{{end}}}
+{{end}}
+{{range .Tables}}
+type {{exported .Name}} struct {
+{{range .Columns}}	{{exported .Name}} {{if .IsNullable}}*{{end}}{{goType .Type}} ` + "`json:\"{{.Name}}\"`" + `
+{{end}}}
+
+var {{exported .Name}}Relationships = []Relationship{
+{{range .ForeignKeys}}	{ConstraintName: "{{.ConstraintName}}", Columns: []string{ {{range $i, $c := .Columns}}{{if $i}}, {{end}}"{{$c}}"{{end}} }, ReferencedTable: "{{.ReferencedTable}}", ReferencedColumns: []string{ {{range $i, $c := .ReferencedColumns}}{{if $i}}, {{end}}"{{$c}}"{{end}} }},
+{{end}}}
+{{end}}
+{{end}}
+`
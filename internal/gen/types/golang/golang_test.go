@@ -0,0 +1,64 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/gen"
+)
+
+func TestRenderGo(t *testing.T) {
+	catalog := gen.Catalog{
+		Schemas: []gen.Schema{{
+			Name: "public",
+			Tables: []gen.Table{{
+				Name: "users",
+				Columns: []gen.Column{
+					{Name: "id", Type: "int4", IsNullable: false, HasDefault: true},
+					{Name: "metadata", Type: "jsonb", IsNullable: true},
+				},
+				ForeignKeys: []gen.ForeignKey{{
+					ConstraintName:    "users_org_id_fkey",
+					Columns:           []string{"org_id"},
+					ReferencedSchema:  "public",
+					ReferencedTable:   "organizations",
+					ReferencedColumns: []string{"id"},
+				}},
+			}},
+			Enums:      []gen.Enum{{Name: "status", Values: []string{"active", "inactive"}}},
+			Composites: []gen.Composite{{Name: "address", Fields: []gen.Column{{Name: "street", Type: "text", IsNullable: true}}}},
+		}},
+	}
+
+	out, err := Target{}.Render(&catalog)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `import "encoding/json"`)
+	assert.Contains(t, string(out), "Id int32")
+	assert.Contains(t, string(out), "Metadata *json.RawMessage")
+	assert.Contains(t, string(out), `StatusActive Status = "active"`)
+	assert.Contains(t, string(out), "type Address struct {")
+	assert.Contains(t, string(out), "Street *string")
+	assert.Contains(t, string(out), "var UsersRelationships = []Relationship{")
+	assert.Contains(t, string(out), `ConstraintName: "users_org_id_fkey"`)
+}
+
+func TestExportedNameSplitsOnSpace(t *testing.T) {
+	assert.Equal(t, "InProgress", exportedName("in progress"))
+	assert.Equal(t, "UserId", exportedName("user_id"))
+}
+
+func TestRenderGoOmitsUnusedJSONImport(t *testing.T) {
+	catalog := gen.Catalog{
+		Schemas: []gen.Schema{{
+			Name: "public",
+			Tables: []gen.Table{{
+				Name:    "users",
+				Columns: []gen.Column{{Name: "id", Type: "int4"}},
+			}},
+		}},
+	}
+
+	out, err := Target{}.Render(&catalog)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(out), "encoding/json")
+}
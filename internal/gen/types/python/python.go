@@ -0,0 +1,150 @@
+package python
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/supabase/cli/internal/gen"
+)
+
+func init() {
+	gen.Register("python", Target{})
+}
+
+// Target renders an introspected catalog as pydantic v2 BaseModels.
+type Target struct{}
+
+func (Target) Render(catalog *gen.Catalog) ([]byte, error) {
+	tmpl, err := template.New("python").Funcs(template.FuncMap{
+		"pyType":      pgTypeToPython,
+		"pyClass":     pythonClassName,
+		"pyConst":     pythonConstName,
+		"pyUpper":     strings.ToUpper,
+		"pyField":     pythonFieldName,
+		"pyIsKeyword": isPythonKeyword,
+	}).Parse(pyTemplate)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, catalog); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// pgToPython maps Postgres udt_name values to their Python equivalent.
+var pgToPython = map[string]string{
+	"int2": "int", "int4": "int", "int8": "int",
+	"float4": "float", "float8": "float", "numeric": "float",
+	"bool": "bool",
+	"json": "dict", "jsonb": "dict",
+	"uuid": "str", "text": "str", "varchar": "str", "bpchar": "str",
+	"date": "str", "timestamp": "str", "timestamptz": "str", "time": "str", "timetz": "str",
+}
+
+func pgTypeToPython(udtName string) string {
+	name := udtName
+	isArray := false
+	if len(name) > 1 && name[0] == '_' {
+		isArray = true
+		name = name[1:]
+	}
+	pyType, ok := pgToPython[name]
+	if !ok {
+		pyType = "Any"
+	}
+	if isArray {
+		pyType = "List[" + pyType + "]"
+	}
+	return pyType
+}
+
+// pythonClassName converts a snake_case Postgres identifier into a PascalCase
+// Python class name, eg. "user_roles" -> "UserRoles".
+func pythonClassName(name string) string {
+	parts := strings.Split(name, "_")
+	for i, p := range parts {
+		if len(p) > 0 {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// pythonConstName converts a Postgres enum label into an upper snake case
+// Python enum member name, eg. "in progress" -> "IN_PROGRESS".
+func pythonConstName(value string) string {
+	return strings.ToUpper(strings.ReplaceAll(value, " ", "_"))
+}
+
+// pythonKeywords lists Python's reserved words, which are not valid
+// pydantic field identifiers as-is.
+var pythonKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true,
+	"assert": true, "async": true, "await": true, "break": true, "class": true,
+	"continue": true, "def": true, "del": true, "elif": true, "else": true,
+	"except": true, "finally": true, "for": true, "from": true, "global": true,
+	"if": true, "import": true, "in": true, "is": true, "lambda": true,
+	"nonlocal": true, "not": true, "or": true, "pass": true, "raise": true,
+	"return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+func isPythonKeyword(name string) bool {
+	return pythonKeywords[name]
+}
+
+// pythonFieldName escapes a Postgres column name that collides with a
+// Python keyword with a trailing underscore (the conventional Python
+// escape, eg. "class" -> "class_"), so the generated model compiles. Pair
+// with Field(alias=...) to keep the wire name unchanged.
+func pythonFieldName(name string) string {
+	if isPythonKeyword(name) {
+		return name + "_"
+	}
+	return name
+}
+
+const pyTemplate = `# Code generated by supabase gen types python. DO NOT EDIT.
+from __future__ import annotations
+
+from enum import Enum
+from typing import Any, List, Optional
+
+from pydantic import BaseModel, Field
+
+
+class Relationship(BaseModel):
+    """Describes a single foreign key constraint, identical in shape across
+    every gen types target."""
+    constraint_name: str
+    columns: List[str]
+    referenced_table: str
+    referenced_columns: List[str]
+
+{{range .Schemas}}
+{{range .Enums}}
+class {{pyClass .Name}}(str, Enum):
+{{range .Values}}    {{pyConst .}} = "{{.}}"
+{{end}}
+
+{{end}}
+{{range .Composites}}
+class {{pyClass .Name}}(BaseModel):
+{{range .Fields}}    {{pyField .Name}}: {{if .IsNullable}}Optional[{{pyType .Type}}] = {{if pyIsKeyword .Name}}Field(default=None, alias="{{.Name}}"){{else}}None{{end}}{{else}}{{pyType .Type}}{{if pyIsKeyword .Name}} = Field(alias="{{.Name}}"){{end}}{{end}}
+{{end}}
+
+{{end}}
+{{range .Tables}}
+class {{pyClass .Name}}(BaseModel):
+{{range .Columns}}    {{pyField .Name}}: {{if .IsNullable}}Optional[{{pyType .Type}}] = {{if pyIsKeyword .Name}}Field(default=None, alias="{{.Name}}"){{else}}None{{end}}{{else}}{{pyType .Type}}{{if pyIsKeyword .Name}} = Field(alias="{{.Name}}"){{end}}{{end}}
+{{end}}
+
+{{pyUpper .Name}}_RELATIONSHIPS: List[Relationship] = [
+{{range .ForeignKeys}}    Relationship(constraint_name="{{.ConstraintName}}", columns=[{{range $i, $c := .Columns}}{{if $i}}, {{end}}"{{$c}}"{{end}}], referenced_table="{{.ReferencedTable}}", referenced_columns=[{{range $i, $c := .ReferencedColumns}}{{if $i}}, {{end}}"{{$c}}"{{end}}]),
+{{end}}]
+
+{{end}}
+{{end}}
+`
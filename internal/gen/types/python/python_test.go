@@ -0,0 +1,50 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/gen"
+)
+
+func TestRenderPython(t *testing.T) {
+	catalog := gen.Catalog{
+		Schemas: []gen.Schema{{
+			Name: "public",
+			Tables: []gen.Table{{
+				Name: "users",
+				Columns: []gen.Column{
+					{Name: "id", Type: "int4", IsNullable: false, HasDefault: true},
+					{Name: "name", Type: "text", IsNullable: true},
+					{Name: "class", Type: "text", IsNullable: false},
+				},
+				ForeignKeys: []gen.ForeignKey{{
+					ConstraintName:    "users_org_id_fkey",
+					Columns:           []string{"org_id"},
+					ReferencedSchema:  "public",
+					ReferencedTable:   "organizations",
+					ReferencedColumns: []string{"id"},
+				}},
+			}},
+			Enums:      []gen.Enum{{Name: "status", Values: []string{"active", "inactive"}}},
+			Composites: []gen.Composite{{Name: "address", Fields: []gen.Column{{Name: "street", Type: "text", IsNullable: true}}}},
+		}},
+	}
+
+	out, err := Target{}.Render(&catalog)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "class Users(BaseModel):")
+	assert.Contains(t, string(out), "id: int")
+	assert.Contains(t, string(out), "name: Optional[str] = None")
+	assert.Contains(t, string(out), `class_: str = Field(alias="class")`)
+	assert.Contains(t, string(out), `ACTIVE = "active"`)
+	assert.Contains(t, string(out), "class Address(BaseModel):")
+	assert.Contains(t, string(out), "street: Optional[str] = None")
+	assert.Contains(t, string(out), "USERS_RELATIONSHIPS: List[Relationship] = [")
+	assert.Contains(t, string(out), `constraint_name="users_org_id_fkey"`)
+}
+
+func TestPythonFieldNameEscapesKeyword(t *testing.T) {
+	assert.Equal(t, "class_", pythonFieldName("class"))
+	assert.Equal(t, "name", pythonFieldName("name"))
+}
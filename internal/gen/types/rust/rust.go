@@ -0,0 +1,154 @@
+package rust
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/supabase/cli/internal/gen"
+)
+
+func init() {
+	gen.Register("rust", Target{})
+}
+
+// Target renders an introspected catalog as serde-tagged Rust structs.
+type Target struct{}
+
+func (Target) Render(catalog *gen.Catalog) ([]byte, error) {
+	tmpl, err := template.New("rust").Funcs(template.FuncMap{
+		"rsType":  pgTypeToRust,
+		"rsIdent": rustIdentName,
+		"rsField": rustFieldName,
+	}).Parse(rsTemplate)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, catalog); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// pgToRust maps Postgres udt_name values to their Rust equivalent.
+var pgToRust = map[string]string{
+	"int2": "i16", "int4": "i32", "int8": "i64",
+	"float4": "f32", "float8": "f64", "numeric": "f64",
+	"bool": "bool",
+	"json": "serde_json::Value", "jsonb": "serde_json::Value",
+	"uuid": "String", "text": "String", "varchar": "String", "bpchar": "String",
+	"date": "String", "timestamp": "String", "timestamptz": "String", "time": "String", "timetz": "String",
+}
+
+func pgTypeToRust(udtName string) string {
+	name := udtName
+	isArray := false
+	if len(name) > 1 && name[0] == '_' {
+		isArray = true
+		name = name[1:]
+	}
+	rsType, ok := pgToRust[name]
+	if !ok {
+		rsType = "serde_json::Value"
+	}
+	if isArray {
+		rsType = "Vec<" + rsType + ">"
+	}
+	return rsType
+}
+
+// rustIdentName converts a snake_case Postgres identifier, or an arbitrary
+// enum label, into a PascalCase Rust type name, eg. "user_roles" ->
+// "UserRoles" and "in progress" -> "InProgress". Splits on any
+// non-alphanumeric rune so labels containing spaces or punctuation still
+// produce a valid identifier.
+func rustIdentName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, p := range parts {
+		if len(p) > 0 {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// rustKeywords lists strict and reserved Rust keywords that are not valid
+// struct field identifiers as-is.
+var rustKeywords = map[string]bool{
+	"as": true, "break": true, "const": true, "continue": true, "crate": true,
+	"dyn": true, "else": true, "enum": true, "extern": true, "false": true,
+	"fn": true, "for": true, "if": true, "impl": true, "in": true, "let": true,
+	"loop": true, "match": true, "mod": true, "move": true, "mut": true,
+	"pub": true, "ref": true, "return": true, "self": true, "Self": true,
+	"static": true, "struct": true, "super": true, "trait": true, "true": true,
+	"type": true, "unsafe": true, "use": true, "where": true, "while": true,
+	"async": true, "await": true,
+}
+
+// rustFieldName escapes a Postgres column name that collides with a Rust
+// keyword using raw identifier syntax, eg. "type" -> "r#type", so the
+// generated struct compiles. Pair with #[serde(rename = "...")] to keep the
+// wire format unchanged.
+func rustFieldName(name string) string {
+	if rustKeywords[name] {
+		return "r#" + name
+	}
+	return name
+}
+
+const rsTemplate = `// Code generated by supabase gen types rust. DO NOT EDIT.
+use serde::{Deserialize, Serialize};
+
+// Relationship describes a single foreign key constraint, identical in
+// shape across every gen types target.
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Relationship {
+    pub constraint_name: String,
+    pub columns: Vec<String>,
+    pub referenced_table: String,
+    pub referenced_columns: Vec<String>,
+}
+{{range .Schemas}}
+{{range .Enums}}
+{{$enum := .}}
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub enum {{rsIdent $enum.Name}} {
+{{range $enum.Values}}    #[serde(rename = "{{.}}")]
+    {{rsIdent .}},
+{{end}}}
+
+{{end}}
+{{range .Composites}}
+{{$composite := .}}
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct {{rsIdent $composite.Name}} {
+{{range $composite.Fields}}    #[serde(rename = "{{.Name}}")]
+    pub {{rsField .Name}}: {{if .IsNullable}}Option<{{rsType .Type}}>{{else}}{{rsType .Type}}{{end}},
+{{end}}}
+
+{{end}}
+{{range .Tables}}
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct {{rsIdent .Name}} {
+{{range .Columns}}    #[serde(rename = "{{.Name}}")]
+    pub {{rsField .Name}}: {{if .IsNullable}}Option<{{rsType .Type}}>{{else}}{{rsType .Type}}{{end}},
+{{end}}}
+
+pub fn {{.Name}}_relationships() -> Vec<Relationship> {
+    vec![
+{{range .ForeignKeys}}        Relationship {
+            constraint_name: "{{.ConstraintName}}".to_string(),
+            columns: vec![{{range $i, $c := .Columns}}{{if $i}}, {{end}}"{{$c}}".to_string(){{end}}],
+            referenced_table: "{{.ReferencedTable}}".to_string(),
+            referenced_columns: vec![{{range $i, $c := .ReferencedColumns}}{{if $i}}, {{end}}"{{$c}}".to_string(){{end}}],
+        },
+{{end}}    ]
+}
+
+{{end}}
+{{end}}
+`
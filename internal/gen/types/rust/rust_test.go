@@ -0,0 +1,47 @@
+package rust
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/gen"
+)
+
+func TestRenderRust(t *testing.T) {
+	catalog := gen.Catalog{
+		Schemas: []gen.Schema{{
+			Name: "public",
+			Tables: []gen.Table{{
+				Name: "users",
+				Columns: []gen.Column{
+					{Name: "id", Type: "int4", IsNullable: false},
+					{Name: "type", Type: "text", IsNullable: true},
+				},
+				ForeignKeys: []gen.ForeignKey{{
+					ConstraintName:    "users_org_id_fkey",
+					Columns:           []string{"org_id"},
+					ReferencedSchema:  "public",
+					ReferencedTable:   "organizations",
+					ReferencedColumns: []string{"id"},
+				}},
+			}},
+			Composites: []gen.Composite{{Name: "address", Fields: []gen.Column{{Name: "street", Type: "text", IsNullable: true}}}},
+		}},
+	}
+
+	out, err := Target{}.Render(&catalog)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "pub struct Users {")
+	assert.Contains(t, string(out), "pub id: i32,")
+	assert.Contains(t, string(out), `#[serde(rename = "type")]`)
+	assert.Contains(t, string(out), "pub r#type: Option<String>,")
+	assert.Contains(t, string(out), "pub struct Address {")
+	assert.Contains(t, string(out), "pub street: Option<String>,")
+	assert.Contains(t, string(out), "pub fn users_relationships() -> Vec<Relationship> {")
+	assert.Contains(t, string(out), `constraint_name: "users_org_id_fkey".to_string(),`)
+}
+
+func TestRustIdentNameSplitsOnSpace(t *testing.T) {
+	assert.Equal(t, "InProgress", rustIdentName("in progress"))
+	assert.Equal(t, "UserRoles", rustIdentName("user_roles"))
+}
@@ -0,0 +1,111 @@
+package typescript
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/supabase/cli/internal/gen"
+)
+
+// pgToTs maps Postgres udt_name values to their TypeScript equivalent, same
+// table pg-meta's typescript generator uses.
+var pgToTs = map[string]string{
+	"int2": "number", "int4": "number", "int8": "number",
+	"float4": "number", "float8": "number", "numeric": "number",
+	"bool": "boolean",
+	"json": "Json", "jsonb": "Json",
+	"uuid": "string", "text": "string", "varchar": "string", "bpchar": "string",
+	"date": "string", "timestamp": "string", "timestamptz": "string", "time": "string", "timetz": "string",
+}
+
+func pgTypeToTs(udtName string) string {
+	name := udtName
+	isArray := false
+	if len(name) > 1 && name[0] == '_' {
+		isArray = true
+		name = name[1:]
+	}
+	tsType, ok := pgToTs[name]
+	if !ok {
+		tsType = "unknown"
+	}
+	if isArray {
+		tsType += "[]"
+	}
+	return tsType
+}
+
+// tsTemplate matches the shape of the Database interface pg-meta's
+// typescript generator emits, so existing consumer snapshots keep passing.
+const tsTemplate = `export type Json = string | number | boolean | null | { [key: string]: Json } | Json[]
+
+export interface Database {
+{{- range .Schemas}}
+  {{.Name}}: {
+    Tables: {
+{{- range .Tables}}
+      {{.Name}}: {
+        Row: {
+{{- range .Columns}}
+          {{.Name}}: {{tsType .Type}}{{if .IsNullable}} | null{{end}}
+{{- end}}
+        }
+        Insert: {
+{{- range .Columns}}
+          {{.Name}}{{if or .IsNullable .HasDefault}}?{{end}}: {{tsType .Type}}{{if .IsNullable}} | null{{end}}
+{{- end}}
+        }
+        Update: {
+{{- range .Columns}}
+          {{.Name}}?: {{tsType .Type}}{{if .IsNullable}} | null{{end}}
+{{- end}}
+        }
+        Relationships: [
+{{- range .ForeignKeys}}
+          {
+            foreignKeyName: "{{.ConstraintName}}"
+            columns: [{{range $i, $c := .Columns}}{{if $i}}, {{end}}"{{$c}}"{{end}}]
+            referencedRelation: "{{.ReferencedTable}}"
+            referencedColumns: [{{range $i, $c := .ReferencedColumns}}{{if $i}}, {{end}}"{{$c}}"{{end}}]
+          }
+{{- end}}
+        ]
+      }
+{{- end}}
+    }
+    Views: {
+      [_ in never]: never
+    }
+    Functions: {
+      [_ in never]: never
+    }
+    Enums: {
+{{- range .Enums}}
+      {{.Name}}: {{range $i, $v := .Values}}{{if $i}} | {{end}}"{{$v}}"{{end}}
+{{- end}}
+    }
+    CompositeTypes: {
+{{- range .Composites}}
+      {{.Name}}: {
+{{- range .Fields}}
+        {{.Name}}: {{tsType .Type}}{{if .IsNullable}} | null{{end}}
+{{- end}}
+      }
+{{- end}}
+    }
+  }
+{{- end}}
+}
+`
+
+func renderTypescript(catalog *gen.Catalog) ([]byte, error) {
+	tmpl, err := template.New("typescript").Funcs(template.FuncMap{"tsType": pgTypeToTs}).Parse(tsTemplate)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, catalog); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,49 @@
+package typescript
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/gen"
+)
+
+func TestPgTypeToTs(t *testing.T) {
+	assert.Equal(t, "number", pgTypeToTs("int4"))
+	assert.Equal(t, "string", pgTypeToTs("text"))
+	assert.Equal(t, "string[]", pgTypeToTs("_text"))
+	assert.Equal(t, "unknown", pgTypeToTs("geometry"))
+}
+
+func TestRenderTypescript(t *testing.T) {
+	catalog := gen.Catalog{
+		Schemas: []gen.Schema{{
+			Name: "public",
+			Tables: []gen.Table{{
+				Name: "users",
+				Columns: []gen.Column{
+					{Name: "id", Type: "int4", IsNullable: false, HasDefault: true},
+					{Name: "name", Type: "text", IsNullable: true},
+				},
+				ForeignKeys: []gen.ForeignKey{{
+					ConstraintName:    "users_org_id_fkey",
+					Columns:           []string{"org_id"},
+					ReferencedSchema:  "public",
+					ReferencedTable:   "organizations",
+					ReferencedColumns: []string{"id"},
+				}},
+			}},
+			Enums:      []gen.Enum{{Name: "status", Values: []string{"active", "inactive"}}},
+			Composites: []gen.Composite{{Name: "address", Fields: []gen.Column{{Name: "street", Type: "text", IsNullable: true}}}},
+		}},
+	}
+
+	out, err := renderTypescript(&catalog)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "id?: number")
+	assert.Contains(t, string(out), "name: string | null")
+	assert.Contains(t, string(out), `status: "active" | "inactive"`)
+	assert.Contains(t, string(out), `foreignKeyName: "users_org_id_fkey"`)
+	assert.Contains(t, string(out), `referencedRelation: "organizations"`)
+	assert.Contains(t, string(out), "address: {")
+	assert.Contains(t, string(out), "street: string | null")
+}
@@ -5,19 +5,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"strings"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/supabase/cli/internal/gen"
 	"github.com/supabase/cli/internal/utils"
 )
 
 var ctx = context.Background()
 
-func Run(useLocal bool, dbUrl string) error {
+func init() {
+	gen.Register("typescript", Target{})
+}
+
+// Target renders an introspected catalog as a supabase-js Database type.
+type Target struct{}
+
+func (Target) Render(catalog *gen.Catalog) ([]byte, error) {
+	return renderTypescript(catalog)
+}
+
+// RunLegacyDocker preserves the pre-pgx pg-meta-in-Docker codegen path,
+// kept around for one release behind --legacy-docker while users migrate
+// to the pgx-based gen.Run.
+func RunLegacyDocker(useLocal bool, dbUrl string) error {
 	if useLocal && dbUrl != "" {
 		return errors.New("Cannot specify both --local and --db-url")
 	} else if !useLocal && dbUrl == "" {
@@ -78,14 +92,7 @@ func Run(useLocal bool, dbUrl string) error {
 		metaImage := utils.GetRegistryImageUrl(utils.PgmetaImage)
 		if _, _, err := utils.Docker.ImageInspectWithRaw(ctx, metaImage); err != nil {
 			fmt.Fprintln(os.Stderr, "Downloading type generator...")
-			out, err := utils.Docker.ImagePull(ctx, metaImage, types.ImagePullOptions{})
-			if err != nil {
-				return err
-			}
-			if _, err := io.ReadAll(out); err != nil {
-				return err
-			}
-			if err := out.Close(); err != nil {
+			if err := utils.PullImageWithProgress(ctx, metaImage, os.Stderr); err != nil {
 				return err
 			}
 			fmt.Fprintln(os.Stderr, "Done downloading type generator")
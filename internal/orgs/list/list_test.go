@@ -1,13 +1,14 @@
 package list
 
 import (
-	"errors"
+	"net/http"
 	"testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/supabase/cli/internal/testing/apitest"
-	"gopkg.in/h2non/gock.v1"
+	"github.com/supabase/cli/test/mocks/supabase"
 )
 
 func TestOrganizationListCommand(t *testing.T) {
@@ -17,17 +18,16 @@ func TestOrganizationListCommand(t *testing.T) {
 		// Setup valid access token
 		token := apitest.RandomAccessToken(t)
 		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
-		// Flush pending mocks after test execution
-		defer gock.Off()
-		gock.New("https://api.supabase.io").
-			Get("/v1/organizations").
-			Reply(200).
-			JSON([]Organization{
-				{
-					Id:   "combined-fuchsia-lion",
-					Name: "Test Organization",
-				},
-			})
+		// Setup fake api.supabase.io
+		mock := supabase.NewServer()
+		defer mock.Close()
+		mock.AddOrganization(supabase.Organization{
+			Id:   "combined-fuchsia-lion",
+			Name: "Test Organization",
+		})
+		oldClient := http.DefaultClient
+		http.DefaultClient = mock.Client()
+		defer func() { http.DefaultClient = oldClient }()
 		// Run test
 		assert.NoError(t, Run(fsys))
 	})
@@ -42,11 +42,10 @@ func TestOrganizationListCommand(t *testing.T) {
 		// Setup valid access token
 		token := apitest.RandomAccessToken(t)
 		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
-		// Flush pending mocks after test execution
-		defer gock.Off()
-		gock.New("https://api.supabase.io").
-			Get("/v1/organizations").
-			ReplyError(errors.New("network error"))
+		// Setup a client that can never reach api.supabase.io
+		oldClient := http.DefaultClient
+		http.DefaultClient = &http.Client{Transport: erroringTransport{}}
+		defer func() { http.DefaultClient = oldClient }()
 		// Run test
 		assert.Error(t, Run(fsys))
 	})
@@ -57,12 +56,15 @@ func TestOrganizationListCommand(t *testing.T) {
 		// Setup valid access token
 		token := apitest.RandomAccessToken(t)
 		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
-		// Flush pending mocks after test execution
-		defer gock.Off()
-		gock.New("https://api.supabase.io").
-			Get("/v1/organizations").
-			Reply(500).
-			JSON(map[string]string{"message": "unavailable"})
+		// Setup fake api.supabase.io
+		mock := supabase.NewServer()
+		defer mock.Close()
+		mock.OrganizationsHandler = func(c *gin.Context) {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "unavailable"})
+		}
+		oldClient := http.DefaultClient
+		http.DefaultClient = mock.Client()
+		defer func() { http.DefaultClient = oldClient }()
 		// Run test
 		assert.Error(t, Run(fsys))
 	})
@@ -73,13 +75,24 @@ func TestOrganizationListCommand(t *testing.T) {
 		// Setup valid access token
 		token := apitest.RandomAccessToken(t)
 		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
-		// Flush pending mocks after test execution
-		defer gock.Off()
-		gock.New("https://api.supabase.io").
-			Get("/v1/organizations").
-			Reply(200).
-			JSON(map[string]string{})
+		// Setup fake api.supabase.io
+		mock := supabase.NewServer()
+		defer mock.Close()
+		mock.OrganizationsHandler = func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{})
+		}
+		oldClient := http.DefaultClient
+		http.DefaultClient = mock.Client()
+		defer func() { http.DefaultClient = oldClient }()
 		// Run test
 		assert.Error(t, Run(fsys))
 	})
 }
+
+// erroringTransport simulates a network error on every request, regardless
+// of host, so Run cannot fall through to the real api.supabase.io.
+type erroringTransport struct{}
+
+func (erroringTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, http.ErrHandlerTimeout
+}
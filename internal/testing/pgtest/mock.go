@@ -28,6 +28,34 @@ type MockConn struct {
 
 	// Channel for reporting all server error
 	errChan chan error
+
+	// True once a PrepareQuery step has been scripted, so Intercept knows to
+	// disable the simple query protocol
+	hasPreparedStatement bool
+
+	// True once a RowDescription has already been sent for the in-flight
+	// extended query, so Reply / ReplyBinary should not send it again
+	describeSent bool
+
+	// True once a Reply / ReplyBinary has emitted its CommandComplete, or a
+	// ReplyError its ErrorResponse, but deferred ReadyForQuery, so a chained Reply or
+	// ReplyError can script another statement of the same simple query
+	pendingReady bool
+
+	// The in-flight extended query's Bind message, kept by reference so
+	// ReplyDescribe can backfill ResultFormatCodes once the result format is
+	// known, since Bind is scripted before the caller has a chance to say
+	// which format the query will be read back in
+	pendingBind *pgproto3.Bind
+}
+
+// Flushes a deferred ReadyForQuery left by a previous Reply / ReplyBinary /
+// ReplyError, so that scripting a new round trip does not miss it.
+func (r *MockConn) flushReady() {
+	if r.pendingReady {
+		r.script.Steps = append(r.script.Steps, pgmock.SendMessage(&pgproto3.ReadyForQuery{TxStatus: 'I'}))
+		r.pendingReady = false
+	}
 }
 
 func (r *MockConn) getStartupMessage(config *pgx.ConnConfig) []pgmock.Step {
@@ -64,7 +92,7 @@ func (r *MockConn) Intercept(config *pgx.ConnConfig) {
 	config.DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
 		return r.server.DialContext(ctx)
 	}
-	config.PreferSimpleProtocol = true
+	config.PreferSimpleProtocol = !r.hasPreparedStatement
 	config.TLSConfig = nil
 	// Add startup message
 	r.script.Steps = append(r.getStartupMessage(config), r.script.Steps...)
@@ -72,12 +100,113 @@ func (r *MockConn) Intercept(config *pgx.ConnConfig) {
 
 // Adds a simple query to the mock connection.
 //
-// TODO: support prepared statements that involve multiple round trips, ie. Parse -> Bind.
+// A single Query may be followed by several Reply / ReplyError chunks, each
+// emitting their own CommandComplete, to script a multi-statement query. Only
+// one ReadyForQuery terminates the round trip, deferred until the next Query
+// or PrepareQuery, or until the mock server starts running the script.
 func (r *MockConn) Query(sql string) *MockConn {
+	r.flushReady()
+	r.describeSent = false
 	r.script.Steps = append(r.script.Steps, pgmock.ExpectMessage(&pgproto3.Query{String: sql}))
 	return r
 }
 
+// Adds a prepared statement query to the mock connection, scripting the
+// Parse -> Bind portion of the extended query protocol so that callers using
+// pgx.Conn.Prepare / QueryRow are not downgraded to the simple protocol. The
+// Bind step is scripted via ReplyBind, so params are encoded exactly as a
+// real pgx client would encode them.
+//
+// Chain ReplyDescribe and Execute to script the remainder of the round trip,
+// eg. PrepareQuery(...).ReplyDescribe(pgtype.TextFormatCode, rows...).Execute(0).Reply(tag, rows...).
+func (r *MockConn) PrepareQuery(name, sql string, params ...interface{}) *MockConn {
+	r.flushReady()
+	r.describeSent = false
+	r.hasPreparedStatement = true
+	oids := make([]uint32, len(params))
+	for i, v := range params {
+		if dt, ok := ci.DataTypeForValue(v); ok {
+			oids[i] = dt.OID
+		}
+	}
+	r.script.Steps = append(
+		r.script.Steps,
+		pgmock.ExpectMessage(&pgproto3.Parse{Name: name, Query: sql, ParameterOIDs: oids}),
+		pgmock.SendMessage(&pgproto3.ParseComplete{}),
+	)
+	return r.ReplyBind(name, params...)
+}
+
+// Adds the Bind step of the extended query protocol, encoding each
+// parameter the way pgx itself does: binary whenever the resolved pgtype
+// supports BinaryEncoder, falling back to text otherwise. Mirror this when
+// asserting on the scripted Bind message, since pgx does not send every
+// parameter in the same format.
+//
+// ResultFormatCodes defaults to text and is backfilled by ReplyDescribe once
+// the result format is known, since a real pgx client decides that before
+// the server has replied to Describe, but callers here only state it by
+// chaining ReplyDescribe after ReplyBind. ReplyBinary does not change it.
+func (r *MockConn) ReplyBind(name string, params ...interface{}) *MockConn {
+	formatCodes := make([]int16, len(params))
+	paramValues := make([][]byte, len(params))
+	for i, v := range params {
+		dt, ok := ci.DataTypeForValue(v)
+		if !ok {
+			continue
+		}
+		if err := dt.Value.Set(v); err != nil {
+			continue
+		}
+		if be, ok := dt.Value.(pgtype.BinaryEncoder); ok {
+			if value, err := be.EncodeBinary(ci, []byte{}); err == nil {
+				formatCodes[i] = pgtype.BinaryFormatCode
+				paramValues[i] = value
+				continue
+			}
+		}
+		if value, err := dt.Value.(pgtype.TextEncoder).EncodeText(ci, []byte{}); err == nil {
+			paramValues[i] = value
+		}
+	}
+	bind := &pgproto3.Bind{
+		DestinationPortal:    "",
+		PreparedStatement:    name,
+		ParameterFormatCodes: formatCodes,
+		Parameters:           paramValues,
+		ResultFormatCodes:    []int16{pgtype.TextFormatCode},
+	}
+	r.pendingBind = bind
+	r.script.Steps = append(
+		r.script.Steps,
+		pgmock.ExpectMessage(bind),
+		pgmock.SendMessage(&pgproto3.BindComplete{}),
+	)
+	return r
+}
+
+// Adds the optional Describe step of the extended query protocol, replying
+// with the row description that would otherwise be sent by Reply /
+// ReplyBinary. format must match whichever of those is chained afterwards
+// (pgtype.TextFormatCode or pgtype.BinaryFormatCode), or the scripted
+// RowDescription won't agree with the DataRow encoding that follows.
+func (r *MockConn) ReplyDescribe(format int16, rows ...map[string]interface{}) *MockConn {
+	if r.pendingBind != nil {
+		r.pendingBind.ResultFormatCodes = []int16{format}
+	}
+	r.script.Steps = append(r.script.Steps, pgmock.ExpectMessage(&pgproto3.Describe{ObjectType: 'P'}))
+	r.sendRowDescription(format, rows...)
+	r.describeSent = true
+	return r
+}
+
+// Adds the Execute step of the extended query protocol. Chain Reply to
+// script the resulting rows, eg. Execute(0).Reply(tag, rows...).
+func (r *MockConn) Execute(maxRows uint32) *MockConn {
+	r.script.Steps = append(r.script.Steps, pgmock.ExpectMessage(&pgproto3.Execute{Portal: "", MaxRows: maxRows}))
+	return r
+}
+
 func getDataTypeSize(v interface{}) int16 {
 	t := reflect.TypeOf(v)
 	k := t.Kind()
@@ -87,33 +216,36 @@ func getDataTypeSize(v interface{}) int16 {
 	return int16(t.Size())
 }
 
-// Adds a server reply using text protocol format.
+// Adds a field description for rows, unless one has already been sent by
+// ReplyDescribe for the in-flight extended query.
 //
-// TODO: support binary protocol
-func (r *MockConn) Reply(tag string, rows ...map[string]interface{}) *MockConn {
-	// Add field description
-	if len(rows) > 0 {
-		var desc pgproto3.RowDescription
-		for k, v := range rows[0] {
-			if dt, ok := ci.DataTypeForValue(v); ok {
-				size := getDataTypeSize(v)
-				desc.Fields = append(desc.Fields, pgproto3.FieldDescription{
-					Name:                 []byte(k),
-					TableOID:             17131,
-					TableAttributeNumber: 1,
-					DataTypeOID:          dt.OID,
-					DataTypeSize:         size,
-					TypeModifier:         -1,
-					Format:               pgtype.TextFormatCode,
-				})
-			}
+// Note: Postgres emits field descriptions even if no rows are returned. However,
+// pgx does not care about it so we do not need to handle the else case.
+func (r *MockConn) sendRowDescription(format int16, rows ...map[string]interface{}) {
+	if len(rows) == 0 {
+		return
+	}
+	var desc pgproto3.RowDescription
+	for k, v := range rows[0] {
+		if dt, ok := ci.DataTypeForValue(v); ok {
+			desc.Fields = append(desc.Fields, pgproto3.FieldDescription{
+				Name:                 []byte(k),
+				TableOID:             17131,
+				TableAttributeNumber: 1,
+				DataTypeOID:          dt.OID,
+				DataTypeSize:         getDataTypeSize(v),
+				TypeModifier:         -1,
+				Format:               format,
+			})
 		}
-		r.script.Steps = append(r.script.Steps, pgmock.SendMessage(&desc))
 	}
-	// Note: Postgres emits field descriptions even if no rows are returned. However,
-	// pgx does not care about it so we do not need to handle the else case.
+	r.script.Steps = append(r.script.Steps, pgmock.SendMessage(&desc))
+}
 
-	// Add row data
+// Adds row data in the given protocol format, encoding each value via
+// BinaryEncoder when format is pgtype.BinaryFormatCode and falling back to
+// TextEncoder for types that do not support binary encoding.
+func (r *MockConn) sendDataRows(format int16, rows ...map[string]interface{}) {
 	for _, data := range rows {
 		var dr pgproto3.DataRow
 		for _, v := range data {
@@ -121,37 +253,76 @@ func (r *MockConn) Reply(tag string, rows ...map[string]interface{}) *MockConn {
 				if err := dt.Value.Set(v); err != nil {
 					continue
 				}
-				if value, err := (dt.Value).(pgtype.TextEncoder).EncodeText(ci, []byte{}); err == nil {
+				var value []byte
+				var err error
+				if be, ok := dt.Value.(pgtype.BinaryEncoder); ok && format == pgtype.BinaryFormatCode {
+					value, err = be.EncodeBinary(ci, []byte{})
+				} else {
+					value, err = dt.Value.(pgtype.TextEncoder).EncodeText(ci, []byte{})
+				}
+				if err == nil {
 					dr.Values = append(dr.Values, value)
 				}
 			}
 		}
 		r.script.Steps = append(r.script.Steps, pgmock.SendMessage(&dr))
 	}
+}
 
-	// Add completion message
-	r.script.Steps = append(
-		r.script.Steps,
-		pgmock.SendMessage(&pgproto3.CommandComplete{CommandTag: []byte(tag)}),
-		pgmock.SendMessage(&pgproto3.ReadyForQuery{TxStatus: 'I'}),
-	)
+// Adds a server reply using text protocol format.
+func (r *MockConn) Reply(tag string, rows ...map[string]interface{}) *MockConn {
+	if !r.describeSent {
+		r.sendRowDescription(pgtype.TextFormatCode, rows...)
+	}
+	r.describeSent = false
+	r.sendDataRows(pgtype.TextFormatCode, rows...)
+
+	// Add completion message, deferring ReadyForQuery in case a further
+	// Reply / ReplyError chunk follows for the same multi-statement query
+	r.script.Steps = append(r.script.Steps, pgmock.SendMessage(&pgproto3.CommandComplete{CommandTag: []byte(tag)}))
+	r.pendingReady = true
+	return r
+}
+
+// Adds a server reply using binary protocol format, for callers that set
+// QueryResultFormats: []int16{pgtype.BinaryFormatCode} on pgx (eg. bulk COPY
+// or performance-sensitive paths).
+func (r *MockConn) ReplyBinary(tag string, rows ...map[string]interface{}) *MockConn {
+	if !r.describeSent {
+		r.sendRowDescription(pgtype.BinaryFormatCode, rows...)
+	}
+	r.describeSent = false
+	r.sendDataRows(pgtype.BinaryFormatCode, rows...)
+
+	// Add completion message, deferring ReadyForQuery in case a further
+	// Reply / ReplyError chunk follows for the same multi-statement query
+	r.script.Steps = append(r.script.Steps, pgmock.SendMessage(&pgproto3.CommandComplete{CommandTag: []byte(tag)}))
+	r.pendingReady = true
+	return r
+}
+
+// Simulates a notice reply from the server, eg. RAISE NOTICE / RAISE WARNING
+// emitted by a migration script. Does not consume the ReadyForQuery slot, so
+// it can be chained before a subsequent Reply or ReplyError.
+func (r *MockConn) ReplyNotice(severity, code, message string) *MockConn {
+	r.script.Steps = append(r.script.Steps, pgmock.SendMessage(&pgproto3.NoticeResponse{
+		Severity:            severity,
+		SeverityUnlocalized: severity,
+		Code:                code,
+		Message:             message,
+	}))
 	return r
 }
 
 // Simulates an error reply from the server.
-//
-// TODO: simulate a notice reply
 func (r *MockConn) ReplyError(code, message string) *MockConn {
-	r.script.Steps = append(
-		r.script.Steps,
-		pgmock.SendMessage(&pgproto3.ErrorResponse{
-			Severity:            "ERROR",
-			SeverityUnlocalized: "ERROR",
-			Code:                code,
-			Message:             message,
-		}),
-		pgmock.SendMessage(&pgproto3.ReadyForQuery{TxStatus: 'I'}),
-	)
+	r.script.Steps = append(r.script.Steps, pgmock.SendMessage(&pgproto3.ErrorResponse{
+		Severity:            "ERROR",
+		SeverityUnlocalized: "ERROR",
+		Code:                code,
+		Message:             message,
+	}))
+	r.pendingReady = true
 	return r
 }
 
@@ -188,6 +359,8 @@ func NewWithStatus(status map[string]string) *MockConn {
 			mock.errChan <- err
 			return
 		}
+		// Flush any ReadyForQuery deferred by the last Reply / ReplyError
+		mock.flushReady()
 		// Always expect clients to terminate the request
 		mock.script.Steps = append(mock.script.Steps, pgmock.ExpectMessage(&pgproto3.Terminate{}))
 		err = mock.script.Run(pgproto3.NewBackend(pgproto3.NewChunkReader(conn), conn))
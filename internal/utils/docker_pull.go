@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"golang.org/x/term"
+)
+
+// PullImageWithProgress pulls ref, decoding the Docker daemon's JSON progress
+// stream and rendering per-layer progress bars to out when it is a TTY,
+// falling back to single-line status updates otherwise. Unlike
+// io.ReadAll(out), the stream is never silently discarded, so the first pull
+// of a large image no longer looks like a silent multi-second stall.
+func PullImageWithProgress(ctx context.Context, ref string, out io.Writer) error {
+	resp, err := Docker.ImagePull(ctx, ref, types.ImagePullOptions{})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	termFd, isTerm := terminalFd(out)
+	return jsonmessage.DisplayJSONMessagesStream(resp, out, termFd, isTerm, nil)
+}
+
+// terminalFd reports the file descriptor backing out and whether it is a
+// TTY, so DisplayJSONMessagesStream can choose between redrawing progress
+// bars in place and emitting single-line status updates. Non-*os.File
+// writers (eg. a bytes.Buffer in tests) are never a TTY.
+func terminalFd(out io.Writer) (uintptr, bool) {
+	f, ok := out.(*os.File)
+	if !ok {
+		return 0, false
+	}
+	fd := f.Fd()
+	return fd, term.IsTerminal(int(fd))
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerminalFd(t *testing.T) {
+	t.Run("a non-file writer is never a TTY", func(t *testing.T) {
+		fd, isTerm := terminalFd(&bytes.Buffer{})
+		assert.False(t, isTerm)
+		assert.Zero(t, fd)
+	})
+
+	t.Run("a regular file is not a TTY", func(t *testing.T) {
+		f, err := os.CreateTemp(t.TempDir(), "pull-progress")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		fd, isTerm := terminalFd(f)
+		assert.False(t, isTerm)
+		assert.Equal(t, f.Fd(), fd)
+	})
+}
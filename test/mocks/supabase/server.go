@@ -2,6 +2,9 @@ package supabase
 
 import (
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,25 +16,118 @@ const (
 	KeyLength   = 40
 )
 
-// Server struct with route handlers
-type Server struct {
-	FunctionsHandler func(c *gin.Context)
-	SecretsHandler   func(c *gin.Context)
+// Organization is the shape returned by GET /v1/organizations.
+type Organization struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
 }
 
-var defaultHandler = func(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"message": "Not implemented",
-	})
+// Project is the shape returned by GET /v1/projects.
+type Project struct {
+	Id             string `json:"id"`
+	OrganizationId string `json:"organization_id"`
+	Name           string `json:"name"`
+	Region         string `json:"region"`
+}
+
+// FunctionDeployment is the shape returned by GET /v1/projects/:id/functions.
+type FunctionDeployment struct {
+	Slug string `json:"slug"`
+	Body string `json:"-"`
 }
 
-// NewServer creates a new server with default handlers
+// Server is a stateful fake of api.supabase.io, so command tests can exercise
+// utils.GetSupabase() end-to-end without patching http.DefaultTransport.
+//
+// Each Handler field overrides the corresponding route's built-in in-memory
+// implementation; leave it nil to use the default stateful behaviour.
+type Server struct {
+	FunctionsHandler     func(c *gin.Context)
+	SecretsHandler       func(c *gin.Context)
+	OrganizationsHandler func(c *gin.Context)
+	ProjectsHandler      func(c *gin.Context)
+	ConfigHandler        func(c *gin.Context)
+
+	mtx sync.Mutex
+
+	organizations []Organization
+	projects      map[string]Project
+	secrets       map[string]map[string]string
+	functions     map[string]map[string]FunctionDeployment
+	configs       map[string]map[string]gin.H
+
+	httpServer *httptest.Server
+}
+
+// NewServer creates a new server with empty in-memory stores. All routes use
+// their built-in stateful implementation until a Handler field is set.
 func NewServer() *Server {
-	s := Server{
-		FunctionsHandler: defaultHandler,
-		SecretsHandler:   defaultHandler,
+	return &Server{
+		projects:  map[string]Project{},
+		secrets:   map[string]map[string]string{},
+		functions: map[string]map[string]FunctionDeployment{},
+		configs:   map[string]map[string]gin.H{},
+	}
+}
+
+// AddOrganization seeds the fake with an organization, returning it unchanged
+// for convenient use in table-driven tests.
+func (s *Server) AddOrganization(org Organization) Organization {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.organizations = append(s.organizations, org)
+	return org
+}
+
+// AddProject seeds the fake with a project.
+func (s *Server) AddProject(project Project) Project {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.projects[project.Id] = project
+	return project
+}
+
+// Client starts the underlying httptest.Server if needed and returns an
+// *http.Client that rewrites requests to api.supabase.io into requests
+// against it, so CLI code paths exercising utils.GetSupabase() can be driven
+// end-to-end without patching http.DefaultTransport.
+func (s *Server) Client() *http.Client {
+	if s.httpServer == nil {
+		s.httpServer = httptest.NewServer(s.NewRouter())
+	}
+	target, err := url.Parse(s.httpServer.URL)
+	if err != nil {
+		panic(err)
+	}
+	return &http.Client{
+		Transport: rewriteHostTransport{
+			target: target,
+			base:   http.DefaultTransport,
+		},
+	}
+}
+
+// Close shuts down the underlying httptest.Server, if one was started.
+func (s *Server) Close() {
+	if s.httpServer != nil {
+		s.httpServer.Close()
+	}
+}
+
+// rewriteHostTransport redirects requests bound for api.supabase.io to the
+// fake server started by Client.
+type rewriteHostTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t rewriteHostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host == "api.supabase.io" {
+		req = req.Clone(req.Context())
+		req.URL.Scheme = t.target.Scheme
+		req.URL.Host = t.target.Host
 	}
-	return &s
+	return t.base.RoundTrip(req)
 }
 
 // NewRouter creating a new router and setting the routes for the server.
@@ -39,30 +135,185 @@ func (s *Server) NewRouter() *gin.Engine {
 	root := gin.Default()
 	router := root.Group("/v1")
 
+	router.GET("/organizations", s.organizationsRoute)
+	router.POST("/organizations", s.organizationsRoute)
+
 	projects := router.Group("/projects")
-	projects.GET("/:id/functions", s.functions)
-	projects.GET("/:id/secrets", s.secrets)
+	projects.GET("", s.projectsRoute)
+	projects.POST("", s.projectsRoute)
+	projects.GET("/:id/api-keys", s.apiKeys)
+
+	projects.GET("/:id/functions", s.functionsRoute)
+	projects.POST("/:id/functions", s.functionsRoute)
+	projects.GET("/:id/functions/:slug", s.functionsRoute)
+	projects.POST("/:id/functions/:slug", s.functionsRoute)
+	projects.DELETE("/:id/functions/:slug", s.functionsRoute)
+
+	projects.GET("/:id/secrets", s.secretsRoute)
+	projects.POST("/:id/secrets", s.secretsRoute)
+	projects.DELETE("/:id/secrets", s.secretsRoute)
+
+	projects.GET("/:id/config/:kind", s.configRoute)
 
 	return root
 }
 
 // project routes
-func (s *Server) functions(c *gin.Context) {
-	if s.FunctionsHandler == nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "handler is nil",
-		})
-	} else {
+
+func (s *Server) organizationsRoute(c *gin.Context) {
+	if s.OrganizationsHandler != nil {
+		s.OrganizationsHandler(c)
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if c.Request.Method == http.MethodPost {
+		var org Organization
+		if err := c.ShouldBindJSON(&org); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		s.organizations = append(s.organizations, org)
+		c.JSON(http.StatusCreated, org)
+		return
+	}
+	c.JSON(http.StatusOK, s.organizations)
+}
+
+func (s *Server) projectsRoute(c *gin.Context) {
+	if s.ProjectsHandler != nil {
+		s.ProjectsHandler(c)
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if c.Request.Method == http.MethodPost {
+		var project Project
+		if err := c.ShouldBindJSON(&project); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		s.projects[project.Id] = project
+		c.JSON(http.StatusCreated, project)
+		return
+	}
+	result := make([]Project, 0, len(s.projects))
+	for _, p := range s.projects {
+		result = append(result, p)
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (s *Server) apiKeys(c *gin.Context) {
+	c.JSON(http.StatusOK, []gin.H{
+		{"name": "anon", "api_key": "anon-key"},
+		{"name": "service_role", "api_key": "service-role-key"},
+	})
+}
+
+func (s *Server) functionsRoute(c *gin.Context) {
+	if s.FunctionsHandler != nil {
 		s.FunctionsHandler(c)
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	projectId := c.Param("id")
+	slug := c.Param("slug")
+	deployments, ok := s.functions[projectId]
+	if !ok {
+		deployments = map[string]FunctionDeployment{}
+		s.functions[projectId] = deployments
+	}
+	switch c.Request.Method {
+	case http.MethodPost:
+		body, err := c.GetRawData()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		deployments[slug] = FunctionDeployment{Slug: slug, Body: string(body)}
+		c.JSON(http.StatusCreated, gin.H{"id": slug})
+	case http.MethodDelete:
+		delete(deployments, slug)
+		c.Status(http.StatusOK)
+	default:
+		if slug != "" {
+			fn, ok := deployments[slug]
+			if !ok {
+				c.JSON(http.StatusNotFound, gin.H{"message": "function not found"})
+				return
+			}
+			c.JSON(http.StatusOK, fn)
+			return
+		}
+		result := make([]FunctionDeployment, 0, len(deployments))
+		for _, fn := range deployments {
+			result = append(result, fn)
+		}
+		c.JSON(http.StatusOK, result)
 	}
 }
 
-func (s *Server) secrets(c *gin.Context) {
-	if s.SecretsHandler == nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "handler is nil",
-		})
-	} else {
+func (s *Server) secretsRoute(c *gin.Context) {
+	if s.SecretsHandler != nil {
 		s.SecretsHandler(c)
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	projectId := c.Param("id")
+	store, ok := s.secrets[projectId]
+	if !ok {
+		store = map[string]string{}
+		s.secrets[projectId] = store
+	}
+	switch c.Request.Method {
+	case http.MethodPost:
+		var body []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		for _, kv := range body {
+			store[kv.Name] = kv.Value
+		}
+		c.Status(http.StatusCreated)
+	case http.MethodDelete:
+		var names []string
+		if err := c.ShouldBindJSON(&names); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		for _, name := range names {
+			delete(store, name)
+		}
+		c.Status(http.StatusOK)
+	default:
+		result := make([]gin.H, 0, len(store))
+		for name := range store {
+			result = append(result, gin.H{"name": name})
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func (s *Server) configRoute(c *gin.Context) {
+	if s.ConfigHandler != nil {
+		s.ConfigHandler(c)
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	projectId := c.Param("id")
+	kind := c.Param("kind")
+	store, ok := s.configs[projectId]
+	if !ok {
+		c.JSON(http.StatusOK, gin.H{})
+		return
 	}
+	c.JSON(http.StatusOK, store[kind])
 }
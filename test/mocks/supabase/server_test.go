@@ -0,0 +1,98 @@
+package supabase
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// These exercise the stateful fake's projects / secrets / functions routes
+// directly against its router, since no command package in this tree yet
+// consumes them the way internal/orgs/list consumes OrganizationsHandler.
+func newTestServer(t *testing.T) (*Server, *httptest.Server) {
+	mock := NewServer()
+	srv := httptest.NewServer(mock.NewRouter())
+	t.Cleanup(srv.Close)
+	return mock, srv
+}
+
+func TestServerProjects(t *testing.T) {
+	t.Run("creates and lists projects", func(t *testing.T) {
+		_, srv := newTestServer(t)
+
+		body, err := json.Marshal(Project{Id: "abc", Name: "Test Project"})
+		require.NoError(t, err)
+		resp, err := http.Post(srv.URL+"/v1/projects", "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		resp, err = http.Get(srv.URL + "/v1/projects")
+		require.NoError(t, err)
+		var projects []Project
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&projects))
+		assert.Len(t, projects, 1)
+		assert.Equal(t, "abc", projects[0].Id)
+	})
+}
+
+func TestServerSecrets(t *testing.T) {
+	t.Run("creates, lists, and deletes secrets", func(t *testing.T) {
+		_, srv := newTestServer(t)
+
+		body, err := json.Marshal([]map[string]string{{"name": "FOO", "value": "bar"}})
+		require.NoError(t, err)
+		resp, err := http.Post(srv.URL+"/v1/projects/abc/secrets", "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		resp, err = http.Get(srv.URL + "/v1/projects/abc/secrets")
+		require.NoError(t, err)
+		var secrets []map[string]string
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&secrets))
+		assert.Len(t, secrets, 1)
+		assert.Equal(t, "FOO", secrets[0]["name"])
+
+		req, err := http.NewRequest(http.MethodDelete, srv.URL+"/v1/projects/abc/secrets", bytes.NewReader([]byte(`["FOO"]`)))
+		require.NoError(t, err)
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, err = http.Get(srv.URL + "/v1/projects/abc/secrets")
+		require.NoError(t, err)
+		secrets = nil
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&secrets))
+		assert.Empty(t, secrets)
+	})
+}
+
+func TestServerFunctions(t *testing.T) {
+	t.Run("deploys, fetches, and deletes a function", func(t *testing.T) {
+		_, srv := newTestServer(t)
+
+		resp, err := http.Post(srv.URL+"/v1/projects/abc/functions/hello", "application/javascript", bytes.NewReader([]byte("export default () => {}")))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		resp, err = http.Get(srv.URL + "/v1/projects/abc/functions/hello")
+		require.NoError(t, err)
+		var fn FunctionDeployment
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&fn))
+		assert.Equal(t, "hello", fn.Slug)
+
+		req, err := http.NewRequest(http.MethodDelete, srv.URL+"/v1/projects/abc/functions/hello", nil)
+		require.NoError(t, err)
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, err = http.Get(srv.URL + "/v1/projects/abc/functions/hello")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}